@@ -0,0 +1,95 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	gotoken "go/token"
+	"math/big"
+	"testing"
+
+	"numgrad.io/lang/expr"
+	"numgrad.io/lang/stmt"
+	"numgrad.io/lang/tipe"
+)
+
+func TestBuiltinMake(t *testing.T) {
+	newChecker := func() *Checker {
+		c := New(gotoken.NewFileSet())
+		c.cur.Objs["IntTable"] = &Obj{Kind: ObjType, Type: &tipe.Table{Type: tipe.Int64}}
+		return c
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		c := newChecker()
+		c.Add(&stmt.Simple{
+			Expr: &expr.Call{
+				Func: &expr.Ident{Name: "make"},
+				Args: []expr.Expr{
+					&expr.Ident{Name: "IntTable"},
+					&expr.BasicLiteral{Value: big.NewInt(3)},
+				},
+			},
+		})
+		if len(c.Errs) != 0 {
+			t.Fatalf("unexpected errors: %v", c.Errs)
+		}
+	})
+
+	t.Run("non-numeric size", func(t *testing.T) {
+		c := newChecker()
+		sz := &expr.BasicLiteral{Value: "oops"}
+		c.Add(&stmt.Simple{
+			Expr: &expr.Call{
+				Func: &expr.Ident{Name: "make"},
+				Args: []expr.Expr{
+					&expr.Ident{Name: "IntTable"},
+					sz,
+				},
+			},
+		})
+		if len(c.Errs) == 0 {
+			t.Fatalf("expected an error for a non-numeric make size argument")
+		}
+	})
+}
+
+func TestBuiltinAppend(t *testing.T) {
+	newChecker := func() *Checker {
+		c := New(gotoken.NewFileSet())
+		c.cur.Objs["xs"] = &Obj{Kind: ObjVar, Type: &tipe.Table{Type: tipe.Int64}}
+		return c
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		c := newChecker()
+		c.Add(&stmt.Simple{
+			Expr: &expr.Call{
+				Func: &expr.Ident{Name: "append"},
+				Args: []expr.Expr{
+					&expr.Ident{Name: "xs"},
+					&expr.BasicLiteral{Value: big.NewInt(3)},
+				},
+			},
+		})
+		if len(c.Errs) != 0 {
+			t.Fatalf("unexpected errors: %v", c.Errs)
+		}
+	})
+
+	t.Run("mismatched element type", func(t *testing.T) {
+		c := newChecker()
+		c.Add(&stmt.Simple{
+			Expr: &expr.Call{
+				Func: &expr.Ident{Name: "append"},
+				Args: []expr.Expr{
+					&expr.Ident{Name: "xs"},
+					&expr.BasicLiteral{Value: "oops"},
+				},
+			},
+		})
+		if len(c.Errs) == 0 {
+			t.Fatalf("expected an error for appending a string to a table of int64")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	gotoken "go/token"
+	"math/big"
+	"testing"
+
+	"numgrad.io/lang/expr"
+	"numgrad.io/lang/stmt"
+	"numgrad.io/lang/tipe"
+)
+
+func TestCompLiteralNamed(t *testing.T) {
+	class := &tipe.Class{
+		FieldNames: []string{"X", "Y"},
+		Fields:     []tipe.Type{tipe.Int64, tipe.Int64},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		c := New(gotoken.NewFileSet())
+		c.Add(&stmt.Simple{
+			Expr: &expr.CompLiteral{
+				Type:  class,
+				Names: []string{"Y"},
+				Elements: []expr.Expr{
+					&expr.BasicLiteral{Value: big.NewInt(5)},
+				},
+			},
+		})
+		if len(c.Errs) != 0 {
+			t.Fatalf("unexpected errors: %v", c.Errs)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		c := New(gotoken.NewFileSet())
+		c.Add(&stmt.Simple{
+			Expr: &expr.CompLiteral{
+				Type:  class,
+				Names: []string{"Z"},
+				Elements: []expr.Expr{
+					&expr.BasicLiteral{Value: big.NewInt(5)},
+				},
+			},
+		})
+		if len(c.Errs) == 0 {
+			t.Fatalf("expected an error for an unknown field name")
+		}
+	})
+}
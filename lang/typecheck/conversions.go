@@ -0,0 +1,77 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	"go/constant"
+	"math"
+
+	"numgrad.io/lang/tipe"
+)
+
+// round coerces the constant value v to basic type t, reporting whether v
+// is representable as t. A false result means v does not fit in t, e.g. an
+// integer constant too large for tipe.Int64, or a float constant that
+// overflows tipe.Float32 or tipe.Float64.
+func round(v constant.Value, t tipe.Basic) (constant.Value, bool) {
+	switch v.Kind() {
+	case constant.Unknown:
+		return v, true
+	case constant.Bool:
+		if t == tipe.Bool || t == tipe.UntypedBool {
+			return v, true
+		}
+		return nil, false
+	case constant.Int:
+		switch t {
+		case tipe.Integer, tipe.UntypedInteger:
+			return v, true
+		case tipe.Float, tipe.UntypedFloat, tipe.UntypedComplex:
+			return v, true
+		case tipe.Num:
+			return v, true
+		case tipe.Int64:
+			if _, ok := constant.Int64Val(v); ok {
+				return v, true
+			}
+			return nil, false
+		case tipe.Float32:
+			return roundFloat32(v)
+		case tipe.Float64:
+			return roundFloat64(v)
+		}
+	case constant.Float:
+		switch t {
+		case tipe.Float, tipe.UntypedFloat, tipe.UntypedComplex:
+			return v, true
+		case tipe.Float32:
+			return roundFloat32(v)
+		case tipe.Float64:
+			return roundFloat64(v)
+		case tipe.Num:
+			return v, true
+		}
+	}
+	// TODO many more comparisons
+	return nil, false
+}
+
+// roundFloat32 and roundFloat64 report false when v overflows the target
+// width, rather than silently returning +/-Inf as the prior implementation
+// did.
+func roundFloat32(v constant.Value) (constant.Value, bool) {
+	r, _ := constant.Float32Val(v)
+	if math.IsInf(float64(r), 0) {
+		return nil, false
+	}
+	return constant.MakeFloat64(float64(r)), true
+}
+
+func roundFloat64(v constant.Value) (constant.Value, bool) {
+	r, _ := constant.Float64Val(v)
+	if math.IsInf(r, 0) {
+		return nil, false
+	}
+	return constant.MakeFloat64(r), true
+}
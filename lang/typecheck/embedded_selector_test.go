@@ -0,0 +1,61 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	gotoken "go/token"
+	"testing"
+
+	"numgrad.io/lang/expr"
+	"numgrad.io/lang/stmt"
+	"numgrad.io/lang/tipe"
+)
+
+func TestEmbeddedSelector(t *testing.T) {
+	inner := &tipe.Class{
+		FieldNames: []string{"Name"},
+		Fields:     []tipe.Type{tipe.String},
+	}
+	outer := &tipe.Class{
+		FieldNames: []string{"Inner"},
+		Fields:     []tipe.Type{inner},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		c := New(gotoken.NewFileSet())
+		c.cur.Objs["o"] = &Obj{Kind: ObjVar, Type: outer}
+		sel := &expr.Selector{
+			Left:  &expr.Ident{Name: "o"},
+			Right: &expr.Ident{Name: "Name"},
+		}
+		c.Add(&stmt.Simple{Expr: sel})
+		if len(c.Errs) != 0 {
+			t.Fatalf("unexpected errors: %v", c.Errs)
+		}
+		if _, ok := c.Selections[sel]; !ok {
+			t.Errorf("c.Selections[sel] missing, want a resolved Selection through the embedded field")
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		innerA := &tipe.Class{FieldNames: []string{"Name"}, Fields: []tipe.Type{tipe.String}}
+		innerB := &tipe.Class{FieldNames: []string{"Name"}, Fields: []tipe.Type{tipe.String}}
+		ambiguousOuter := &tipe.Class{
+			FieldNames: []string{"A", "B"},
+			Fields:     []tipe.Type{innerA, innerB},
+		}
+
+		c := New(gotoken.NewFileSet())
+		c.cur.Objs["o"] = &Obj{Kind: ObjVar, Type: ambiguousOuter}
+		c.Add(&stmt.Simple{
+			Expr: &expr.Selector{
+				Left:  &expr.Ident{Name: "o"},
+				Right: &expr.Ident{Name: "Name"},
+			},
+		})
+		if len(c.Errs) == 0 {
+			t.Fatalf("expected an ambiguous selector error")
+		}
+	})
+}
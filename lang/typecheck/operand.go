@@ -0,0 +1,74 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	"go/constant"
+
+	"numgrad.io/lang/expr"
+	"numgrad.io/lang/tipe"
+)
+
+// operandMode describes what an operand denotes: a constant, a variable,
+// a builtin function, a type itself, a function, or something invalid.
+// It is modeled on the operand in go/types.
+type operandMode int
+
+const (
+	modeInvalid operandMode = iota
+	modeVoid
+	modeConst
+	modeVar
+	modeBuiltin
+	modeTypeExpr
+	modeFunc
+)
+
+// operand holds what is known about an expr.Expr as it is typechecked:
+// its mode, its type, its constant value (if any), and the expression it
+// came from.
+type operand struct {
+	mode operandMode
+	typ  tipe.Type
+	val  constant.Value
+	expr expr.Expr
+}
+
+// setConst sets o to the constant value val of type t, which may be an
+// untyped or a typed type.
+func (o *operand) setConst(val constant.Value, t tipe.Type) {
+	o.mode = modeConst
+	o.typ = t
+	o.val = val
+}
+
+// setTypeExpr sets o to denote the type t itself, as in a type conversion
+// or the type position of a composite literal.
+func (o *operand) setTypeExpr(t tipe.Type) {
+	o.mode = modeTypeExpr
+	o.typ = t
+}
+
+// assignableTo reports whether o, as-is, can be assigned to a variable of
+// type t. It does not constrain untyped constants; callers that need to
+// round an untyped constant against t should do so before relying on this.
+func (o *operand) assignableTo(t tipe.Type) bool {
+	return AssignableTo(o.typ, t)
+}
+
+// convertibleTo reports whether o can be explicitly converted to type t.
+func (o *operand) convertibleTo(t tipe.Type) bool {
+	return ConvertibleTo(o.typ, t)
+}
+
+// representableAs reports whether the constant held by o can be
+// represented by a value of basic type t, returning the (possibly
+// rounded) constant value if so. It is only meaningful when o.mode is
+// modeConst.
+func (o *operand) representableAs(t tipe.Basic) (constant.Value, bool) {
+	if o.mode != modeConst {
+		return nil, false
+	}
+	return round(o.val, t)
+}
@@ -0,0 +1,126 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	"numgrad.io/lang/tipe"
+)
+
+// Identical reports whether x and y denote the same type. It understands
+// the structural composite types (*tipe.Func, *tipe.Class, *tipe.Table,
+// *tipe.Tuple) that tipe.Equal does not fully cover, recursing into their
+// element types, and falls back to tipe.Equal for everything else.
+func Identical(x, y tipe.Type) bool {
+	if x == y {
+		return true
+	}
+	switch x := x.(type) {
+	case *tipe.Func:
+		y, ok := y.(*tipe.Func)
+		if !ok {
+			return false
+		}
+		return identicalTuple(x.Params, y.Params) && identicalTuple(x.Results, y.Results)
+	case *tipe.Class:
+		y, ok := y.(*tipe.Class)
+		if !ok {
+			return false
+		}
+		if len(x.FieldNames) != len(y.FieldNames) {
+			return false
+		}
+		for i := range x.FieldNames {
+			if x.FieldNames[i] != y.FieldNames[i] || !Identical(x.Fields[i], y.Fields[i]) {
+				return false
+			}
+		}
+		if len(x.MethodNames) != len(y.MethodNames) {
+			return false
+		}
+		for i := range x.MethodNames {
+			if x.MethodNames[i] != y.MethodNames[i] || !Identical(x.Methods[i], y.Methods[i]) {
+				return false
+			}
+		}
+		return true
+	case *tipe.Table:
+		y, ok := y.(*tipe.Table)
+		if !ok {
+			return false
+		}
+		return Identical(x.Type, y.Type)
+	case *tipe.Tuple:
+		return identicalTuple(x, y)
+	default:
+		return tipe.Equal(x, y)
+	}
+}
+
+func identicalTuple(x, y *tipe.Tuple) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if len(x.Elems) != len(y.Elems) {
+		return false
+	}
+	for i := range x.Elems {
+		if !Identical(x.Elems[i], y.Elems[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssignableTo reports whether a value of type src can be assigned to a
+// variable of type dst: either the types are Identical, or dst is an
+// interface that src implements.
+func AssignableTo(src, dst tipe.Type) bool {
+	if Identical(src, dst) {
+		return true
+	}
+	return Implements(src, dst)
+}
+
+// ConvertibleTo reports whether a value of type src can be explicitly
+// converted to type dst.
+func ConvertibleTo(src, dst tipe.Type) bool {
+	if AssignableTo(src, dst) {
+		return true
+	}
+	// TODO several other forms of convertibility, e.g. maps where keys and
+	// values are identical.
+
+	// numerics can be converted to one another
+	if tipe.IsNumeric(dst) && tipe.IsNumeric(src) {
+		return true
+	}
+
+	return false
+}
+
+// Implements reports whether type t implements interface type iface. It
+// reports false if iface is not an interface or t is not a class.
+func Implements(t, iface tipe.Type) bool {
+	in, ok := iface.(*tipe.Interface)
+	if !ok {
+		return false
+	}
+	cls, ok := t.(*tipe.Class)
+	if !ok {
+		return false
+	}
+	for i, name := range in.MethodNames {
+		found := false
+		for j, mname := range cls.MethodNames {
+			if mname == name && Identical(cls.Methods[j], in.Methods[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	"testing"
+
+	"numgrad.io/lang/tipe"
+)
+
+// TestIdenticalClassMethods checks that Identical distinguishes two
+// classes with identical fields but different method sets, e.g. two Go
+// structs of the same shape translated from different packages. Before
+// this, Identical compared only FieldNames/Fields, so such classes
+// compared equal and AssignableTo/ConvertibleTo would wrongly accept one
+// in place of the other.
+func TestIdenticalClassMethods(t *testing.T) {
+	base := func() *tipe.Class {
+		return &tipe.Class{
+			FieldNames: []string{"X", "Y"},
+			Fields:     []tipe.Type{tipe.Int64, tipe.Int64},
+		}
+	}
+
+	same := base()
+	same.MethodNames = []string{"String"}
+	same.Methods = []tipe.Type{&tipe.Func{Results: &tipe.Tuple{Elems: []tipe.Type{tipe.String}}}}
+
+	sameAgain := base()
+	sameAgain.MethodNames = []string{"String"}
+	sameAgain.Methods = []tipe.Type{&tipe.Func{Results: &tipe.Tuple{Elems: []tipe.Type{tipe.String}}}}
+
+	if !Identical(same, sameAgain) {
+		t.Errorf("Identical(same, sameAgain) = false, want true")
+	}
+
+	differentMethods := base()
+	differentMethods.MethodNames = []string{"GoString"}
+	differentMethods.Methods = []tipe.Type{&tipe.Func{Results: &tipe.Tuple{Elems: []tipe.Type{tipe.String}}}}
+
+	if Identical(same, differentMethods) {
+		t.Errorf("Identical(same, differentMethods) = true, want false")
+	}
+
+	noMethods := base()
+	if Identical(same, noMethods) {
+		t.Errorf("Identical(same, noMethods) = true, want false")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	gotoken "go/token"
+	"math/big"
+	"testing"
+
+	"numgrad.io/lang/expr"
+	"numgrad.io/lang/stmt"
+	"numgrad.io/lang/tipe"
+)
+
+// TestRenameVar checks the common case: a declared variable with one
+// later use gets an edit at both its definition and its use.
+func TestRenameVar(t *testing.T) {
+	c := New(gotoken.NewFileSet())
+	c.Add(&stmt.Assign{
+		Decl: true,
+		Left: []expr.Expr{&expr.Ident{Name: "x"}},
+		Right: []expr.Expr{
+			&expr.BasicLiteral{Value: big.NewInt(1)},
+		},
+	})
+	c.Add(&stmt.Simple{Expr: &expr.Ident{Name: "x"}})
+
+	obj := c.Lookup("x")
+	edits, err := c.Rename(obj, "y")
+	if err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if len(edits) != 2 {
+		t.Fatalf("len(edits) = %d, want 2 (def + use)", len(edits))
+	}
+	for _, e := range edits {
+		if e.NewText != "y" {
+			t.Errorf("edit.NewText = %q, want %q", e.NewText, "y")
+		}
+	}
+}
+
+// TestRenameShadow checks that Rename rejects a rename that would shadow
+// another Obj visible in the same scope as the renamed Obj.
+func TestRenameShadow(t *testing.T) {
+	c := New(gotoken.NewFileSet())
+	c.Add(&stmt.Assign{
+		Decl: true,
+		Left: []expr.Expr{&expr.Ident{Name: "x"}},
+		Right: []expr.Expr{
+			&expr.BasicLiteral{Value: big.NewInt(1)},
+		},
+	})
+	c.Add(&stmt.Assign{
+		Decl: true,
+		Left: []expr.Expr{&expr.Ident{Name: "q"}},
+		Right: []expr.Expr{
+			&expr.BasicLiteral{Value: big.NewInt(2)},
+		},
+	})
+
+	obj := c.Lookup("x")
+	if _, err := c.Rename(obj, "q"); err == nil {
+		t.Fatalf("Rename(x, %q) = nil error, want shadowing error", "q")
+	}
+}
+
+// TestRenameClassDecl checks that renaming a class Obj also rewrites the
+// class's own name on its *stmt.ClassDecl, not just its (here, absent)
+// uses.
+func TestRenameClassDecl(t *testing.T) {
+	c := New(gotoken.NewFileSet())
+	c.Add(&stmt.ClassDecl{Name: "Foo", Type: &tipe.Class{}})
+
+	obj := c.Lookup("Foo")
+	edits, err := c.Rename(obj, "Bar")
+	if err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if len(edits) != 1 {
+		t.Fatalf("len(edits) = %d, want 1 (decl name)", len(edits))
+	}
+	if edits[0].NewText != "Bar" {
+		t.Errorf("edits[0].NewText = %q, want %q", edits[0].NewText, "Bar")
+	}
+}
+
+// TestRenameFuncDecl is TestRenameClassDecl's counterpart for a named
+// top-level function, whose name also lives only on the declaration
+// (*expr.FuncLiteral), never as an *expr.Ident in Defs/Uses.
+func TestRenameFuncDecl(t *testing.T) {
+	c := New(gotoken.NewFileSet())
+	c.Add(&stmt.Simple{
+		Expr: &expr.FuncLiteral{
+			Name: "Greet",
+			Type: &tipe.Func{},
+			Body: &stmt.Block{},
+		},
+	})
+
+	obj := c.Lookup("Greet")
+	edits, err := c.Rename(obj, "Hail")
+	if err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if len(edits) != 1 {
+		t.Fatalf("len(edits) = %d, want 1 (decl name)", len(edits))
+	}
+	if edits[0].NewText != "Hail" {
+		t.Errorf("edits[0].NewText = %q, want %q", edits[0].NewText, "Hail")
+	}
+}
+
+// TestRenameNoReferences checks that Rename rejects renaming an Obj that
+// is neither referenced anywhere nor has a Decl to rewrite.
+func TestRenameNoReferences(t *testing.T) {
+	c := New(gotoken.NewFileSet())
+	obj := c.Lookup("len")
+	if _, err := c.Rename(obj, "length"); err == nil {
+		t.Fatalf("Rename(len, ...) = nil error, want error")
+	}
+}
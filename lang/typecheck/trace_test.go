@@ -0,0 +1,59 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	"bytes"
+	gotoken "go/token"
+	"math/big"
+	"strings"
+	"testing"
+
+	"numgrad.io/lang/expr"
+	"numgrad.io/lang/stmt"
+)
+
+// TestTrace checks that enabling Trace sends diagnostic output to
+// TraceWriter, and that it stays silent when Trace is left off.
+func TestTrace(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		c := New(gotoken.NewFileSet())
+		c.Trace = true
+		c.TraceWriter = &buf
+		c.Add(&stmt.Simple{Expr: &expr.BasicLiteral{Value: big.NewInt(1)}})
+		if buf.Len() == 0 {
+			t.Fatalf("Trace produced no output, want a trace of the checked statement")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		c := New(gotoken.NewFileSet())
+		c.TraceWriter = &buf
+		c.Add(&stmt.Simple{Expr: &expr.BasicLiteral{Value: big.NewInt(1)}})
+		if buf.Len() != 0 {
+			t.Fatalf("TraceWriter = %q, want no output when Trace is unset", buf.String())
+		}
+	})
+}
+
+// TestDump checks that Dump (and the String method built on it) reports
+// the checker's recorded types.
+func TestDump(t *testing.T) {
+	c := New(gotoken.NewFileSet())
+	c.Add(&stmt.Assign{
+		Decl:  true,
+		Left:  []expr.Expr{&expr.Ident{Name: "x"}},
+		Right: []expr.Expr{&expr.BasicLiteral{Value: big.NewInt(1)}},
+	})
+
+	got := c.String()
+	if !strings.Contains(got, "typecheck.Checker{") {
+		t.Fatalf("Dump output = %q, want it to start a typecheck.Checker{ block", got)
+	}
+	if !strings.Contains(got, "x") {
+		t.Errorf("Dump output = %q, want it to mention the scope's x Obj", got)
+	}
+}
@@ -11,7 +11,11 @@ import (
 	"go/importer"
 	gotoken "go/token"
 	gotypes "go/types"
+	"io"
 	"math/big"
+	"os"
+	"sort"
+	"strings"
 
 	"numgrad.io/lang/expr"
 	"numgrad.io/lang/stmt"
@@ -21,20 +25,48 @@ import (
 
 type Checker struct {
 	// TODO: we could put these on our AST. Should we?
-	Types   map[expr.Expr]tipe.Type
-	Defs    map[*expr.Ident]*Obj
-	Values  map[expr.Expr]constant.Value
-	NumSpec map[expr.Expr]tipe.Basic // *tipe.Call, *tipe.CompLiteral -> numeric basic type
-	Errs    []error
+	Types      map[expr.Expr]tipe.Type
+	Defs       map[*expr.Ident]*Obj
+	Uses       map[*expr.Ident]*Obj
+	Values     map[expr.Expr]constant.Value
+	NumSpec    map[expr.Expr]tipe.Basic // *tipe.Call, *tipe.CompLiteral -> numeric basic type
+	Selections map[*expr.Selector]*Selection
+	Errs       []Error
+
+	// Trace enables diagnostic tracing of the checker's recursion through
+	// stmt/expr/exprPartial/resolve/assign/convert/constrainUntyped. When
+	// true, trace output is written to TraceWriter (os.Stderr if nil).
+	Trace       bool
+	TraceWriter io.Writer
 
 	cur *Scope
+
+	// scopeOf records, for every *expr.Ident in Defs or Uses, the scope
+	// that was current when it was resolved. Rename consults it to check
+	// for shadowing in the scope actually visible at each use, rather
+	// than in whatever scope c.cur has unwound to once checking finishes.
+	scopeOf map[*expr.Ident]*Scope
+
+	// goTypeCache memoizes the translation of *gotypes.Named types so that
+	// recursive Go types (e.g. a struct with a field of pointer-to-itself)
+	// terminate instead of looping forever.
+	goTypeCache map[*gotypes.Named]tipe.Type
+
+	fset *gotoken.FileSet
+
+	traceDepth int
 }
 
-func New() *Checker {
+func New(fset *gotoken.FileSet) *Checker {
 	return &Checker{
-		Types:  make(map[expr.Expr]tipe.Type),
-		Defs:   make(map[*expr.Ident]*Obj),
-		Values: make(map[expr.Expr]constant.Value),
+		Types:       make(map[expr.Expr]tipe.Type),
+		Defs:        make(map[*expr.Ident]*Obj),
+		Uses:        make(map[*expr.Ident]*Obj),
+		Values:      make(map[expr.Expr]constant.Value),
+		Selections:  make(map[*expr.Selector]*Selection),
+		scopeOf:     make(map[*expr.Ident]*Scope),
+		goTypeCache: make(map[*gotypes.Named]tipe.Type),
+		fset:        fset,
 		cur: &Scope{
 			Parent: base,
 			Objs:   make(map[string]*Obj),
@@ -42,38 +74,64 @@ func New() *Checker {
 	}
 }
 
-type partialMode int
+// trace writes a depth-indented diagnostic line, modeled on the tracing
+// facility in go/types. It is a no-op unless c.Trace is set.
+func (c *Checker) trace(format string, args ...interface{}) {
+	if !c.Trace {
+		return
+	}
+	w := c.TraceWriter
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, "%s%s\n", strings.Repeat(".  ", c.traceDepth), fmt.Sprintf(format, args...))
+}
 
-const (
-	modeInvalid partialMode = iota
-	modeVoid
-	modeConst
-	modeVar
-	modeBuiltin
-	modeTypeExpr
-	modeFunc
-)
+// traceEnter logs entry to a traced function and returns a func to be
+// called via defer to log its exit, indenting everything traced in
+// between by one level.
+func (c *Checker) traceEnter(format string, args ...interface{}) func(result ...interface{}) {
+	c.trace(format, args...)
+	c.traceDepth++
+	return func(result ...interface{}) {
+		c.traceDepth--
+		if len(result) > 0 {
+			c.trace("=> %v", result[0])
+		}
+	}
+}
+
+// Error is a positioned diagnostic produced by the Checker.
+type Error struct {
+	Pos gotoken.Position
+	Msg string
+}
 
-type partial struct {
-	mode partialMode
-	typ  tipe.Type
-	val  constant.Value
-	expr expr.Expr
+func (e Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// poser is satisfied by every expr.Expr and stmt.Stmt node, giving the
+// position to report a diagnostic against.
+type poser interface {
+	Pos() gotoken.Pos
 }
 
 func (c *Checker) stmt(s stmt.Stmt, retType *tipe.Tuple) {
+	defer c.traceEnter("stmt %s", s.Sexp())()
+
 	switch s := s.(type) {
 	case *stmt.Assign:
 		if len(s.Left) != len(s.Right) {
 			panic("TODO artity mismatch, i.e. x, y := f()")
 		}
-		var partials []partial
+		var operands []operand
 		for _, rhs := range s.Right {
-			partials = append(partials, c.expr(rhs))
+			operands = append(operands, c.expr(rhs))
 		}
 		if s.Decl {
 			for i, lhs := range s.Left {
-				p := partials[i]
+				p := operands[i]
 				if isUntyped(p.typ) {
 					c.constrainUntyped(&p, defaultType(p.typ))
 				}
@@ -82,11 +140,12 @@ func (c *Checker) stmt(s stmt.Stmt, retType *tipe.Tuple) {
 					Type: p.typ,
 				}
 				c.Defs[lhs.(*expr.Ident)] = obj
+				c.scopeOf[lhs.(*expr.Ident)] = c.cur
 				c.cur.Objs[lhs.(*expr.Ident).Name] = obj
 			}
 		} else {
 			for i, lhs := range s.Left {
-				p := partials[i]
+				p := operands[i]
 				lhsP := c.expr(lhs)
 				c.assign(&p, lhsP.typ)
 			}
@@ -100,6 +159,7 @@ func (c *Checker) stmt(s stmt.Stmt, retType *tipe.Tuple) {
 				obj := &Obj{
 					Kind: ObjVar,
 					Type: p.typ,
+					Decl: fn,
 				}
 				// TODO: c.Defs?
 				c.cur.Objs[fn.Name] = obj
@@ -141,14 +201,14 @@ func (c *Checker) stmt(s stmt.Stmt, retType *tipe.Tuple) {
 		var usesNum bool
 		var resolved bool
 		for i, f := range s.Type.Fields {
-			s.Type.Fields[i], resolved = c.resolve(f)
+			s.Type.Fields[i], resolved = c.resolve(s, f)
 			usesNum = usesNum || tipe.UsesNum(s.Type.Fields[i])
 			if !resolved {
 				return
 			}
 		}
 		for i, f := range s.Type.Methods {
-			s.Type.Methods[i], resolved = c.resolve(f)
+			s.Type.Methods[i], resolved = c.resolve(s, f)
 			usesNum = usesNum || tipe.UsesNum(s.Type.Methods[i])
 			if !resolved {
 				return
@@ -182,50 +242,50 @@ func (c *Checker) stmt(s stmt.Stmt, retType *tipe.Tuple) {
 
 	case *stmt.Return:
 		if retType == nil || len(s.Exprs) > len(retType.Elems) {
-			c.errorf("too many arguments to return")
+			c.errorf(s, "too many arguments to return")
 		}
-		var partials []partial
+		var operands []operand
 		for i, e := range s.Exprs {
-			partials = append(partials, c.expr(e))
-			c.constrainUntyped(&partials[i], retType.Elems[i])
+			operands = append(operands, c.expr(e))
+			c.constrainUntyped(&operands[i], retType.Elems[i])
 		}
-		for _, p := range partials {
+		for _, p := range operands {
 			if p.mode == modeInvalid {
 				return
 			}
 		}
 		want := retType.Elems
-		if len(want) == 0 && len(partials) == 0 {
+		if len(want) == 0 && len(operands) == 0 {
 			return
 		}
 		var got []tipe.Type
-		if tup, ok := partials[0].typ.(*tipe.Tuple); ok {
-			if len(partials) != 1 {
-				c.errorf("multi-value %s in single-value context", partials[0])
+		if tup, ok := operands[0].typ.(*tipe.Tuple); ok {
+			if len(operands) != 1 {
+				c.errorf(operands[0].expr, "multi-value %s in single-value context", operands[0])
 				return
 			}
 			got = tup.Elems
 		} else {
-			for _, p := range partials {
+			for _, p := range operands {
 				if _, ok := p.typ.(*tipe.Tuple); ok {
-					c.errorf("multi-value %s in single-value context", partials[0])
+					c.errorf(p.expr, "multi-value %s in single-value context", operands[0])
 					return
 				}
 				got = append(got, p.typ)
 			}
 		}
 		if len(got) > len(want) {
-			c.errorf("too many arguments to return")
+			c.errorf(s, "too many arguments to return")
 			return
 		}
 		if len(got) < len(want) {
-			c.errorf("too few arguments to return")
+			c.errorf(s, "too few arguments to return")
 			return
 		}
 
 		for i := range want {
-			if !tipe.Equal(got[i], want[i]) {
-				c.errorf("cannot use %s as %s (%T) in return argument", got[i], want[i])
+			if !Identical(got[i], want[i]) {
+				c.errorf(s, "cannot use %s as %s (%T) in return argument", got[i], want[i])
 				return
 			}
 		}
@@ -239,13 +299,13 @@ func (c *Checker) stmt(s stmt.Stmt, retType *tipe.Tuple) {
 }
 
 func (c *Checker) goPackage(gopkg *gotypes.Package) *tipe.Go {
-	names := gopkg.Scope().Names()
+	scope := gopkg.Scope()
 
 	pkg := &tipe.Package{
 		Exports: make(map[string]tipe.Type),
 	}
-	for _, name := range names {
-		pkg.Exports[name] = nil // TODO
+	for _, name := range scope.Names() {
+		pkg.Exports[name] = c.fromGoType(scope.Lookup(name).Type())
 	}
 
 	return &tipe.Go{
@@ -254,11 +314,110 @@ func (c *Checker) goPackage(gopkg *gotypes.Package) *tipe.Go {
 	}
 }
 
+// fromGoType translates a go/types.Type, as produced by importing a real Go
+// package, into the equivalent tipe.Type. Named types are memoized on c via
+// goTypeCache so that recursive definitions terminate and so that the same
+// Go type always maps to the same tipe.Type.
+func (c *Checker) fromGoType(t gotypes.Type) tipe.Type {
+	switch t := t.(type) {
+	case *gotypes.Basic:
+		return fromGoBasic(t)
+	case *gotypes.Named:
+		if cached, ok := c.goTypeCache[t]; ok {
+			return cached
+		}
+		named := &tipe.Named{Name: t.Obj().Name()}
+		c.goTypeCache[t] = named
+		named.Type = c.fromGoType(t.Underlying())
+		return named
+	case *gotypes.Signature:
+		return c.fromGoSignature(t)
+	case *gotypes.Struct:
+		return c.fromGoStruct(t)
+	case *gotypes.Pointer:
+		return &tipe.Pointer{Elem: c.fromGoType(t.Elem())}
+	case *gotypes.Slice:
+		return &tipe.Table{Type: c.fromGoType(t.Elem())}
+	case *gotypes.Map:
+		return &tipe.Map{Key: c.fromGoType(t.Key()), Type: c.fromGoType(t.Elem())}
+	case *gotypes.Interface:
+		return c.fromGoInterface(t)
+	default:
+		// TODO: chan, array, tuple
+		return tipe.Invalid
+	}
+}
+
+func fromGoBasic(t *gotypes.Basic) tipe.Type {
+	switch t.Kind() {
+	case gotypes.Bool:
+		return tipe.Bool
+	case gotypes.Int, gotypes.Int8, gotypes.Int16, gotypes.Int32, gotypes.Int64,
+		gotypes.Uint, gotypes.Uint8, gotypes.Uint16, gotypes.Uint32, gotypes.Uint64, gotypes.Uintptr:
+		return tipe.Int64 // TODO: distinct sized/unsigned integer types
+	case gotypes.Float32:
+		return tipe.Float32
+	case gotypes.Float64:
+		return tipe.Float64
+	case gotypes.String:
+		return tipe.String
+	case gotypes.UntypedBool:
+		return tipe.UntypedBool
+	case gotypes.UntypedInt, gotypes.UntypedRune:
+		return tipe.UntypedInteger
+	case gotypes.UntypedFloat:
+		return tipe.UntypedFloat
+	case gotypes.UntypedComplex:
+		return tipe.UntypedComplex
+	case gotypes.UntypedString:
+		return tipe.String
+	default:
+		return tipe.Invalid
+	}
+}
+
+func (c *Checker) fromGoSignature(sig *gotypes.Signature) *tipe.Func {
+	fn := &tipe.Func{}
+	if n := sig.Params().Len(); n > 0 {
+		fn.Params = &tipe.Tuple{Elems: make([]tipe.Type, n)}
+		for i := 0; i < n; i++ {
+			fn.Params.Elems[i] = c.fromGoType(sig.Params().At(i).Type())
+		}
+	}
+	if n := sig.Results().Len(); n > 0 {
+		fn.Results = &tipe.Tuple{Elems: make([]tipe.Type, n)}
+		for i := 0; i < n; i++ {
+			fn.Results.Elems[i] = c.fromGoType(sig.Results().At(i).Type())
+		}
+	}
+	return fn
+}
+
+func (c *Checker) fromGoStruct(s *gotypes.Struct) *tipe.Class {
+	class := &tipe.Class{}
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		class.FieldNames = append(class.FieldNames, f.Name())
+		class.Fields = append(class.Fields, c.fromGoType(f.Type()))
+	}
+	return class
+}
+
+func (c *Checker) fromGoInterface(it *gotypes.Interface) *tipe.Interface {
+	iface := &tipe.Interface{}
+	for i := 0; i < it.NumMethods(); i++ {
+		m := it.Method(i)
+		iface.MethodNames = append(iface.MethodNames, m.Name())
+		iface.Methods = append(iface.Methods, c.fromGoSignature(m.Type().(*gotypes.Signature)))
+	}
+	return iface
+}
+
 func (c *Checker) checkImport(s *stmt.Import) {
 	if s.FromGo {
 		pkg, err := importer.Default().Import(s.Path)
 		if err != nil {
-			c.errorf("importing go package: %v", err)
+			c.errorf(s, "importing go package: %v", err)
 			return
 		}
 		if s.Name == "" {
@@ -269,14 +428,17 @@ func (c *Checker) checkImport(s *stmt.Import) {
 			Type: c.goPackage(pkg),
 			// TODO Decl?
 		}
-		fmt.Printf("typechecking import %s\n", s.Name)
+		c.trace("typechecking import %s", s.Name)
 		c.cur.Objs[s.Name] = obj
 	} else {
-		c.errorf("TODO import of non-Go package")
+		c.errorf(s, "TODO import of non-Go package")
 	}
 }
 
-func (c *Checker) expr(e expr.Expr) (p partial) {
+func (c *Checker) expr(e expr.Expr) (p operand) {
+	done := c.traceEnter("expr %s", e.Sexp())
+	defer func() { done(fmt.Sprintf("mode=%v typ=%s", p.mode, p.typ)) }()
+
 	// TODO more mode adjustment
 	p = c.exprPartial(e)
 	if p.mode == modeConst {
@@ -286,10 +448,13 @@ func (c *Checker) expr(e expr.Expr) (p partial) {
 	return p
 }
 
-func (c *Checker) resolve(t tipe.Type) (ret tipe.Type, resolved bool) {
+func (c *Checker) resolve(node poser, t tipe.Type) (ret tipe.Type, resolved bool) {
+	done := c.traceEnter("resolve %s", t)
+	defer func() { done(fmt.Sprintf("ret=%s resolved=%v", ret, resolved)) }()
+
 	switch t := t.(type) {
 	case *tipe.Table:
-		t.Type, resolved = c.resolve(t.Type)
+		t.Type, resolved = c.resolve(node, t.Type)
 		return t, resolved
 	case *tipe.Unresolved:
 		if t.Package != "" {
@@ -298,11 +463,11 @@ func (c *Checker) resolve(t tipe.Type) (ret tipe.Type, resolved bool) {
 		}
 		obj := c.cur.LookupRec(t.Name)
 		if obj == nil {
-			c.errorf("type %s not declared", t.Name)
+			c.errorf(node, "type %s not declared", t.Name)
 			return t, false
 		}
 		if obj.Kind != ObjType {
-			c.errorf("symbol %s is not a type", t.Name)
+			c.errorf(node, "symbol %s is not a type", t.Name)
 			return t, false
 		}
 		return obj.Type, true
@@ -312,39 +477,41 @@ func (c *Checker) resolve(t tipe.Type) (ret tipe.Type, resolved bool) {
 	}
 }
 
-func (c *Checker) exprPartial(e expr.Expr) (p partial) {
-	//fmt.Printf("exprPartial(%s)\n", e.Sexp())
+func (c *Checker) exprPartial(e expr.Expr) (p operand) {
+	done := c.traceEnter("exprPartial %s", e.Sexp())
+	defer func() { done(fmt.Sprintf("mode=%v typ=%s", p.mode, p.typ)) }()
+
 	p.expr = e
 	switch e := e.(type) {
 	case *expr.Ident:
 		obj := c.cur.LookupRec(e.Name)
 		if obj == nil {
 			p.mode = modeInvalid
-			c.errorf("undeclared identifier: %s", e.Name)
+			c.errorf(e, "undeclared identifier: %s", e.Name)
 			return p
 		}
-		c.Defs[e] = obj // TODO Defs is more than definitions? rename?
-		// TODO: is a partial's mode just an ObjKind?
-		// not every partial has an Obj, but we could reuse the type.
+		c.Uses[e] = obj
+		c.scopeOf[e] = c.cur
+		// TODO: is a operand's mode just an ObjKind?
+		// not every operand has an Obj, but we could reuse the type.
 		switch obj.Kind {
 		case ObjVar:
 			p.mode = modeVar
+			p.typ = obj.Type
 		case ObjType:
-			p.mode = modeTypeExpr
+			p.setTypeExpr(obj.Type)
+		case ObjBuiltin:
+			p.mode = modeBuiltin
+			p.typ = obj.Type
 		}
-		p.typ = obj.Type
 		return p
 	case *expr.BasicLiteral:
 		// TODO: use constant.Value in BasicLiteral directly.
 		switch v := e.Value.(type) {
 		case *big.Int:
-			p.mode = modeConst
-			p.typ = tipe.UntypedInteger
-			p.val = constant.MakeFromLiteral(v.String(), gotoken.INT, 0)
+			p.setConst(constant.MakeFromLiteral(v.String(), gotoken.INT, 0), tipe.UntypedInteger)
 		case *big.Float:
-			p.mode = modeConst
-			p.typ = tipe.UntypedFloat
-			p.val = constant.MakeFromLiteral(v.String(), gotoken.FLOAT, 0)
+			p.setConst(constant.MakeFromLiteral(v.String(), gotoken.FLOAT, 0), tipe.UntypedFloat)
 		case string:
 			p.mode = modeVar
 			p.typ = tipe.String
@@ -355,7 +522,7 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 		defer c.popScope()
 		if e.Type.Params != nil {
 			for i, t := range e.Type.Params.Elems {
-				e.Type.Params.Elems[i], _ = c.resolve(t)
+				e.Type.Params.Elems[i], _ = c.resolve(e, t)
 				obj := &Obj{
 					Kind: ObjVar,
 					Type: t,
@@ -365,7 +532,7 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 		}
 		if e.Type.Results != nil {
 			for i, t := range e.Type.Results.Elems {
-				e.Type.Results.Elems[i], _ = c.resolve(t)
+				e.Type.Results.Elems[i], _ = c.resolve(e, t)
 			}
 		}
 		p.typ = e.Type
@@ -375,7 +542,7 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 	case *expr.CompLiteral:
 		p.mode = modeVar
 		className := fmt.Sprintf("%s", e.Type)
-		if t, resolved := c.resolve(e.Type); resolved {
+		if t, resolved := c.resolve(e, e.Type); resolved {
 			e.Type = t
 			p.typ = t
 		} else {
@@ -384,11 +551,11 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 		}
 		class, isClass := e.Type.(*tipe.Class)
 		if !isClass {
-			c.errorf("cannot construct type %s with a composite literal", e.Type)
+			c.errorf(e, "cannot construct type %s with a composite literal", e.Type)
 			p.mode = modeInvalid
 			return p
 		}
-		elemsp := make([]partial, len(e.Elements))
+		elemsp := make([]operand, len(e.Elements))
 		for i, elem := range e.Elements {
 			elemsp[i] = c.expr(elem)
 			if elemsp[i].mode == modeInvalid {
@@ -398,7 +565,7 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 		}
 		if len(e.Names) == 0 {
 			if len(e.Elements) != len(class.Fields) {
-				c.errorf("wrong number of elements, %d, when %s expects %d", len(e.Elements), className, len(class.Fields))
+				c.errorf(e, "wrong number of elements, %d, when %s expects %d", len(e.Elements), className, len(class.Fields))
 				p.mode = modeInvalid
 				return p
 			}
@@ -410,7 +577,38 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 				}
 			}
 		} else {
-			panic("TODO: named CompLiteral")
+			if len(e.Names) != len(e.Elements) {
+				c.errorf(e, "wrong number of names (%d) for elements (%d) in composite literal", len(e.Names), len(e.Elements))
+				p.mode = modeInvalid
+				return p
+			}
+			seen := make(map[string]bool, len(e.Names))
+			for i, name := range e.Names {
+				if seen[name] {
+					c.errorf(e, "duplicate field name %s in %s literal", name, className)
+					p.mode = modeInvalid
+					return p
+				}
+				seen[name] = true
+				fieldIdx := -1
+				for j, fn := range class.FieldNames {
+					if fn == name {
+						fieldIdx = j
+						break
+					}
+				}
+				if fieldIdx == -1 {
+					c.errorf(e, "unknown field %s in %s literal", name, className)
+					p.mode = modeInvalid
+					return p
+				}
+				c.assign(&elemsp[i], class.Fields[fieldIdx])
+				if elemsp[i].mode == modeInvalid {
+					p.mode = modeInvalid
+					return p
+				}
+			}
+			// Fields not named above keep their zero value.
 		}
 		if p.mode != modeInvalid {
 			p.expr = e
@@ -421,10 +619,10 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 		p.mode = modeVar
 
 		var elemType tipe.Type
-		if t, resolved := c.resolve(e.Type); resolved {
+		if t, resolved := c.resolve(e, e.Type); resolved {
 			t, isTable := t.(*tipe.Table)
 			if !isTable {
-				c.errorf("type %s is not a table", t)
+				c.errorf(e, "type %s is not a table", t)
 				p.mode = modeInvalid
 				return p
 			}
@@ -451,13 +649,13 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 		// Check everyone agrees on the width.
 		w := len(e.Rows[0])
 		if len(e.ColNames) > 0 && len(e.ColNames) != w {
-			c.errorf("table literal has %d column names but a width of %d", len(e.ColNames), w)
+			c.errorf(e, "table literal has %d column names but a width of %d", len(e.ColNames), w)
 			p.mode = modeInvalid
 			return p
 		}
 		for _, r := range e.Rows {
 			if len(r) != w {
-				c.errorf("table literal has rows of different lengths (%d and %d)", w, len(r))
+				c.errorf(e, "table literal has rows of different lengths (%d and %d)", w, len(r))
 				p.mode = modeInvalid
 				return p
 			}
@@ -524,18 +722,18 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 
 			if len(e.Args) != len(params) {
 				p.mode = modeInvalid
-				c.errorf("wrong number of arguments (%d) to function %s", len(e.Args), funct)
+				c.errorf(e, "wrong number of arguments (%d) to function %s", len(e.Args), funct)
 			}
 
 			if p.mode != modeInvalid {
-				var argsp []partial
+				var argsp []operand
 				for i, arg := range e.Args {
 					t := params[i]
 					argp := c.expr(arg)
 					c.convert(&argp, t)
 					if argp.mode == modeInvalid {
 						p.mode = modeInvalid
-						c.errorf("cannot use type %s as type %s in argument to function", argp.typ, t)
+						c.errorf(argp.expr, "cannot use type %s as type %s in argument to function", argp.typ, t)
 						break
 					}
 					argsp = append(argsp, argp)
@@ -551,11 +749,11 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 			// type conversion
 			if len(e.Args) == 0 {
 				p.mode = modeInvalid
-				c.errorf("type conversion to %s is missing an argument", p.typ)
+				c.errorf(e, "type conversion to %s is missing an argument", p.typ)
 				return p
 			} else if len(e.Args) != 1 {
 				p.mode = modeInvalid
-				c.errorf("type conversion to %s has too many arguments", p.typ)
+				c.errorf(e, "type conversion to %s has too many arguments", p.typ)
 				return p
 			}
 			t := p.typ
@@ -566,6 +764,23 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 			c.convert(&p, t)
 			p.expr = e
 			return p
+		case modeBuiltin:
+			ident, ok := e.Func.(*expr.Ident)
+			if !ok {
+				p.mode = modeInvalid
+				c.errorf(e, "builtin call has non-identifier callee %s", e.Func)
+				return p
+			}
+			var args []operand
+			for _, a := range e.Args {
+				argp := c.expr(a)
+				if argp.mode == modeInvalid {
+					p.mode = modeInvalid
+					return p
+				}
+				args = append(args, argp)
+			}
+			return builtins[ident.Name](c, e, args)
 		default:
 			panic(fmt.Sprintf("unreachable, unknown call mode: %v", p.mode))
 		}
@@ -574,10 +789,30 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 		if left.mode == modeInvalid {
 			return left
 		}
+		if goPkg, ok := left.typ.(*tipe.Go); ok {
+			right := e.Right.Name
+			t, ok := goPkg.Equivalent.Exports[right]
+			if !ok {
+				p.mode = modeInvalid
+				c.errorf(e, "%s undefined (package %s has no exported identifier %s)", e, goPkg.GoPkg.Path(), right)
+				return p
+			}
+			switch obj := goPkg.GoPkg.Scope().Lookup(right).(type) {
+			case *gotypes.Func:
+				p.mode = modeFunc
+				p.typ = t
+			case *gotypes.Const:
+				p.setConst(obj.Val(), t)
+			default:
+				p.mode = modeVar
+				p.typ = t
+			}
+			return p
+		}
 		cls, ok := left.typ.(*tipe.Class)
 		if !ok {
 			p.mode = modeInvalid
-			c.errorf("%s undefined (type %s is not a class)", e, cls)
+			c.errorf(e, "%s undefined (type %s is not a class)", e, cls)
 			return p
 		}
 		right := e.Right.Name
@@ -595,8 +830,17 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 				return
 			}
 		}
+		if sel, ambiguous := c.resolveEmbeddedSelector(e, cls, right); sel != nil {
+			c.Selections[e] = sel
+			p.mode = modeVar
+			p.typ = sel.Type
+			return p
+		} else if ambiguous {
+			p.mode = modeInvalid
+			return p
+		}
 		p.mode = modeInvalid
-		c.errorf("%s undefined (type %s has no field or method %s)", e, cls, right)
+		c.errorf(e, "%s undefined (type %s has no field or method %s)", e, cls, right)
 		return p
 	case *expr.Shell:
 		p.mode = modeVoid
@@ -605,7 +849,66 @@ func (c *Checker) exprPartial(e expr.Expr) (p partial) {
 	panic(fmt.Sprintf("expr TODO: %T", e))
 }
 
-func (c *Checker) assign(p *partial, t tipe.Type) {
+// Selection records how a *expr.Selector resolved to a field or method
+// promoted through one or more embedded fields. Indices is the path of
+// field indices to walk, outermost class first, to reach the selection;
+// when the selection is a method, the last index is into Methods.
+type Selection struct {
+	Type    tipe.Type
+	Indices []int
+}
+
+// resolveEmbeddedSelector searches breadth-first through cls's fields whose
+// type is itself a *tipe.Class, looking for a field or method named name.
+// If exactly one match exists at the shallowest depth it is returned. If
+// more than one match exists at that depth, resolveEmbeddedSelector reports
+// an ambiguous-selector error itself and returns ambiguous=true.
+func (c *Checker) resolveEmbeddedSelector(node poser, cls *tipe.Class, name string) (sel *Selection, ambiguous bool) {
+	type frame struct {
+		cls     *tipe.Class
+		indices []int
+	}
+	queue := []frame{{cls, nil}}
+	for len(queue) > 0 {
+		var next []frame
+		var found []*Selection
+		for _, fr := range queue {
+			for i, fn := range fr.cls.FieldNames {
+				path := append(append([]int{}, fr.indices...), i)
+				if fn == name {
+					found = append(found, &Selection{Type: fr.cls.Fields[i], Indices: path})
+				}
+				if embedded, ok := fr.cls.Fields[i].(*tipe.Class); ok {
+					next = append(next, frame{embedded, path})
+				}
+			}
+			for i, mn := range fr.cls.MethodNames {
+				if mn == name {
+					path := append(append([]int{}, fr.indices...), i)
+					found = append(found, &Selection{Type: fr.cls.Methods[i], Indices: path})
+				}
+			}
+		}
+		switch len(found) {
+		case 0:
+			queue = next
+		case 1:
+			return found[0], false
+		default:
+			c.errorf(node, "ambiguous selector %s", name)
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// assign is a thin wrapper over operand.assignableTo: it checks that p can
+// be assigned to a variable of type t, constraining p's type if needed, and
+// reports a diagnostic against p.expr if not.
+func (c *Checker) assign(p *operand, t tipe.Type) {
+	done := c.traceEnter("assign %s to %s", p.expr.Sexp(), t)
+	defer func() { done(fmt.Sprintf("mode=%v typ=%s", p.mode, p.typ)) }()
+
 	if p.mode == modeInvalid {
 		return
 	}
@@ -613,30 +916,32 @@ func (c *Checker) assign(p *partial, t tipe.Type) {
 		c.constrainUntyped(p, t)
 		return
 	}
-	if !tipe.Equal(p.typ, t) { // TODO interfaces, etc
-		c.errorf("cannot assign %s to %s", p.typ, t)
+	if !p.assignableTo(t) {
+		c.errorf(p.expr, "cannot assign %s to %s", p.typ, t)
 		p.mode = modeInvalid
 	}
 }
 
-func (c *Checker) convert(p *partial, t tipe.Type) {
-	fmt.Printf("Checker.convert(p=%#+v, t=%s)\n", p, t)
-	_, tIsConst := t.(tipe.Basic)
-	if p.mode == modeConst && tIsConst {
-		// TODO or integer -> string conversion
-		fmt.Printf("convert round p.typ=%s, p.val=%s, t=%s\n", p.typ, p.val, t)
-		if round(p.val, t.(tipe.Basic)) == nil {
-			// p.val does not fit in t
-			c.errorf("constant %s does not fit in %s", p.val, t)
+// convert is a thin wrapper over operand.convertibleTo and
+// operand.representableAs: it checks that p can be converted to type t,
+// constraining p's type if needed, and reports a diagnostic against p.expr
+// if not.
+func (c *Checker) convert(p *operand, t tipe.Type) {
+	done := c.traceEnter("convert %s to %s", p.expr.Sexp(), t)
+	defer func() { done(fmt.Sprintf("mode=%v typ=%s", p.mode, p.typ)) }()
+
+	if basic, ok := t.(tipe.Basic); ok && p.mode == modeConst {
+		if _, ok := p.representableAs(basic); !ok {
+			c.errorf(p.expr, "constant %s does not fit in %s", p.val, t)
 			p.mode = modeInvalid
 			return
 		}
 	}
 
-	if !convertible(p.typ, t) {
+	if !p.convertibleTo(t) {
 		// TODO p is assignable to t, lots of possibilities
 		// (interface satisfaction, etc)
-		c.errorf("cannot use %s as %s", p.typ, t)
+		c.errorf(p.expr, "cannot use %s as %s", p.typ, t)
 		p.mode = modeInvalid
 		return
 	}
@@ -648,22 +953,10 @@ func (c *Checker) convert(p *partial, t tipe.Type) {
 	}
 }
 
-func convertible(dst, src tipe.Type) bool {
-	if dst == src {
-		return true
-	}
-	// TODO several other forms of "identical" types,
-	// e.g. maps where keys and value are identical,
-
-	// numerics can be converted to one another
-	if tipe.IsNumeric(dst) && tipe.IsNumeric(src) {
-		return true
-	}
+func (c *Checker) constrainUntyped(p *operand, t tipe.Type) {
+	done := c.traceEnter("constrainUntyped %s to %s", p.expr.Sexp(), t)
+	defer func() { done(fmt.Sprintf("mode=%v typ=%s", p.mode, p.typ)) }()
 
-	return false
-}
-
-func (c *Checker) constrainUntyped(p *partial, t tipe.Type) {
 	if p.mode == modeInvalid || isTyped(p.typ) || t == tipe.Invalid {
 		return
 	}
@@ -678,16 +971,17 @@ func (c *Checker) constrainUntyped(p *partial, t tipe.Type) {
 		case t == tipe.Num && (p.typ == tipe.UntypedInteger || p.typ == tipe.UntypedFloat):
 			// promote untyped int or float to num type parameter
 		case t != p.typ:
-			c.errorf("cannot convert %s to %s", p.typ, t)
+			c.errorf(p.expr, "cannot convert %s to %s", p.typ, t)
 		}
 	} else {
 		switch t := t.(type) {
 		case tipe.Basic:
 			switch p.mode {
 			case modeConst:
-				p.val = round(p.val, t)
-				if p.val == nil {
-					c.errorf("cannot convert const %s to %s", p.typ, t)
+				if val, ok := p.representableAs(t); ok {
+					p.val = val
+				} else {
+					c.errorf(p.expr, "cannot convert const %s to %s", p.typ, t)
 					// TODO more details about why
 				}
 			case modeVar:
@@ -729,9 +1023,11 @@ func (c *Checker) constrainExprType(e expr.Expr, t tipe.Type) {
 	c.Types[e] = t
 }
 
-func (c *Checker) errorf(format string, args ...interface{}) {
-	err := fmt.Errorf(format, args...)
-	c.Errs = append(c.Errs, err)
+func (c *Checker) errorf(node poser, format string, args ...interface{}) {
+	c.Errs = append(c.Errs, Error{
+		Pos: c.fset.Position(node.Pos()),
+		Msg: fmt.Sprintf(format, args...),
+	})
 }
 
 func (c *Checker) pushScope() {
@@ -764,55 +1060,6 @@ func convGoOp(op token.Token) gotoken.Token {
 	}
 }
 
-func round(v constant.Value, t tipe.Basic) constant.Value {
-	switch v.Kind() {
-	case constant.Unknown:
-		return v
-	case constant.Bool:
-		if t == tipe.Bool || t == tipe.UntypedBool {
-			return v
-		} else {
-			return nil
-		}
-	case constant.Int:
-		switch t {
-		case tipe.Integer, tipe.UntypedInteger:
-			return v
-		case tipe.Float, tipe.UntypedFloat, tipe.UntypedComplex:
-			return v
-		case tipe.Num:
-			return v
-		case tipe.Int64:
-			if _, ok := constant.Int64Val(v); ok {
-				return v
-			} else {
-				return nil
-			}
-		case tipe.Float32:
-			r, _ := constant.Float32Val(v)
-			return constant.MakeFloat64(float64(r))
-		case tipe.Float64:
-			r, _ := constant.Float64Val(v)
-			return constant.MakeFloat64(float64(r))
-		}
-	case constant.Float:
-		switch t {
-		case tipe.Float, tipe.UntypedFloat, tipe.UntypedComplex:
-			return v
-		case tipe.Float32:
-			r, _ := constant.Float32Val(v)
-			return constant.MakeFloat64(float64(r))
-		case tipe.Float64:
-			r, _ := constant.Float64Val(v)
-			return constant.MakeFloat64(float64(r))
-		case tipe.Num:
-			return v
-		}
-	}
-	// TODO many more comparisons
-	return nil
-}
-
 func (c *Checker) Add(s stmt.Stmt) {
 	c.stmt(s, nil)
 }
@@ -821,29 +1068,159 @@ func (c *Checker) Lookup(name string) *Obj {
 	return c.cur.LookupRec(name)
 }
 
-func (c *Checker) String() string {
-	buf := new(bytes.Buffer)
-	buf.WriteString("typecheck.Checker{\n")
-	buf.WriteString("\tTypes: map[expr.Expr]tipe.Type{\n")
+// SortedErrors returns c.Errs sorted by source position, suitable for
+// presenting diagnostics to a user in file order.
+func (c *Checker) SortedErrors() []Error {
+	errs := make([]Error, len(c.Errs))
+	copy(errs, c.Errs)
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Pos.Offset < errs[j].Pos.Offset
+	})
+	return errs
+}
+
+// Edit describes a single textual replacement required by a refactoring
+// operation such as Rename.
+type Edit struct {
+	Pos, End gotoken.Position
+	NewText  string
+}
+
+// identsForObj returns every *expr.Ident in Defs and Uses that refers to obj.
+func (c *Checker) identsForObj(obj *Obj) []*expr.Ident {
+	var idents []*expr.Ident
+	for ident, o := range c.Defs {
+		if o == obj {
+			idents = append(idents, ident)
+		}
+	}
+	for ident, o := range c.Uses {
+		if o == obj {
+			idents = append(idents, ident)
+		}
+	}
+	return idents
+}
+
+// declNamePos returns the position of a declaration's own name, given the
+// keyword that introduces it (e.g. "func " or "class "). obj.Decl nodes
+// store their name as a plain string (fn.Name, class.Name) rather than a
+// positioned *expr.Ident, so Pos() only ever gives us the start of the
+// declaring keyword; the name is assumed to immediately follow it,
+// separated by the single space the parser always emits there.
+func declNamePos(declPos gotoken.Pos, keyword string) gotoken.Pos {
+	return declPos + gotoken.Pos(len(keyword))
+}
+
+// declNameEdit returns the edit that rewrites a declaration's own name to
+// newName, if obj.Decl is a node Rename knows how to locate a name
+// within. ok is false for Decl values of a kind Rename doesn't recognize.
+func (c *Checker) declNameEdit(decl interface{}, newName string) (edit Edit, ok bool) {
+	var pos gotoken.Pos
+	var oldName string
+	switch d := decl.(type) {
+	case *expr.FuncLiteral:
+		pos = declNamePos(d.Pos(), "func ")
+		oldName = d.Name
+	case *stmt.ClassDecl:
+		pos = declNamePos(d.Pos(), "class ")
+		oldName = d.Name
+	default:
+		return Edit{}, false
+	}
+	return Edit{
+		Pos:     c.fset.Position(pos),
+		End:     c.fset.Position(pos + gotoken.Pos(len(oldName))),
+		NewText: newName,
+	}, true
+}
+
+// Rename computes the source edits required to rename obj to newName
+// everywhere it is defined or used. It does not mutate the AST; it is the
+// caller's responsibility to apply the returned edits. Rename rejects the
+// rename if newName already resolves to a different Obj in the scope in
+// which any *expr.Ident in Defs or Uses referring to obj was resolved.
+// The name on obj.Decl itself is not checked against the scope it was
+// declared in, so a declaration with no such idents is never rejected
+// for shadowing.
+func (c *Checker) Rename(obj *Obj, newName string) (edits []Edit, err error) {
+	idents := c.identsForObj(obj)
+
+	var declEdit Edit
+	var hasDeclEdit bool
+	if obj.Decl != nil {
+		declEdit, hasDeclEdit = c.declNameEdit(obj.Decl, newName)
+	}
+
+	if len(idents) == 0 && !hasDeclEdit {
+		return nil, fmt.Errorf("typecheck: rename: no identifiers refer to %v", obj)
+	}
+
+	for _, ident := range idents {
+		scope := c.scopeOf[ident]
+		if shadow := scope.LookupRec(newName); shadow != nil && shadow != obj {
+			return nil, fmt.Errorf("typecheck: rename: %s is already declared in a visible scope", newName)
+		}
+	}
+
+	for _, ident := range idents {
+		edits = append(edits, Edit{
+			Pos:     c.fset.Position(ident.Pos()),
+			End:     c.fset.Position(ident.End()),
+			NewText: newName,
+		})
+	}
+
+	if hasDeclEdit {
+		edits = append(edits, declEdit)
+	}
+
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Pos.Offset < edits[j].Pos.Offset
+	})
+	return edits, nil
+}
+
+// Dump writes a human-readable representation of c.Types, c.Defs,
+// c.Values, and the current scope with each of its enclosing scopes to w.
+// Scopes that have already been popped are not written. It is meant for
+// debugging, alongside Trace.
+func (c *Checker) Dump(w io.Writer) {
+	fmt.Fprintf(w, "typecheck.Checker{\n")
+	fmt.Fprintf(w, "\tTypes: map[expr.Expr]tipe.Type{\n")
 	for k, v := range c.Types {
-		fmt.Fprintf(buf, "\t\t(%p)%s: %s\n", k, k.Sexp(), v.Sexp())
+		fmt.Fprintf(w, "\t\t(%p)%s: %s\n", k, k.Sexp(), v.Sexp())
 	}
-	buf.WriteString("\t},\n")
-	buf.WriteString("\tDefs: map[*expr.Ident]*Obj{\n")
+	fmt.Fprintf(w, "\t},\n")
+	fmt.Fprintf(w, "\tDefs: map[*expr.Ident]*Obj{\n")
 	for k, v := range c.Defs {
 		t := "niltype"
 		if v.Type != nil {
 			t = v.Type.Sexp()
 		}
-		fmt.Fprintf(buf, "\t\t(%p)%s: (%p)*Obj{Kind: %v, Type:%s}\n", k, k.Sexp(), v, v.Kind, t)
+		fmt.Fprintf(w, "\t\t(%p)%s: (%p)*Obj{Kind: %v, Type:%s}\n", k, k.Sexp(), v, v.Kind, t)
 	}
-	buf.WriteString("\t},\n")
-	buf.WriteString("\tValues : map[expr.Expr]constant.Value{\n")
+	fmt.Fprintf(w, "\t},\n")
+	fmt.Fprintf(w, "\tValues : map[expr.Expr]constant.Value{\n")
 	for k, v := range c.Values {
-		fmt.Fprintf(buf, "\t\t(%p)%s: %s\n", k, k.Sexp(), v)
+		fmt.Fprintf(w, "\t\t(%p)%s: %s\n", k, k.Sexp(), v)
 	}
-	buf.WriteString("\t},\n")
-	buf.WriteString("}")
+	fmt.Fprintf(w, "\t},\n")
+	fmt.Fprintf(w, "\tScopes: [\n")
+	for s := c.cur; s != nil; s = s.Parent {
+		fmt.Fprintf(w, "\t\t(%p)Scope{\n", s)
+		for name, obj := range s.Objs {
+			fmt.Fprintf(w, "\t\t\t%s: %v\n", name, obj.Kind)
+		}
+		fmt.Fprintf(w, "\t\t},\n")
+	}
+	fmt.Fprintf(w, "\t],\n")
+	fmt.Fprintf(w, "}")
+}
+
+func (c *Checker) String() string {
+	buf := new(bytes.Buffer)
+	c.Dump(buf)
 	return buf.String()
 }
 
@@ -864,12 +1241,187 @@ func (s *Scope) LookupRec(name string) *Obj {
 	return nil
 }
 
+// base is the universe scope, seeded with the language's builtin functions.
+var base = &Scope{
+	Objs: map[string]*Obj{
+		"make":   {Kind: ObjBuiltin},
+		"new":    {Kind: ObjBuiltin},
+		"len":    {Kind: ObjBuiltin},
+		"cap":    {Kind: ObjBuiltin},
+		"append": {Kind: ObjBuiltin},
+		"copy":   {Kind: ObjBuiltin},
+		"delete": {Kind: ObjBuiltin},
+	},
+}
+
+// builtins dispatches a builtin call to its typechecking implementation.
+// Each entry's args have already been typechecked via c.expr.
+var builtins = map[string]func(c *Checker, call *expr.Call, args []operand) operand{
+	"make":   builtinMake,
+	"new":    builtinNew,
+	"len":    builtinLen,
+	"cap":    builtinCap,
+	"append": builtinAppend,
+	"copy":   builtinCopy,
+	"delete": builtinDelete,
+}
+
+func builtinMake(c *Checker, call *expr.Call, args []operand) operand {
+	p := operand{mode: modeInvalid, expr: call}
+	if len(args) == 0 {
+		c.errorf(call, "missing argument to make")
+		return p
+	}
+	if args[0].mode != modeTypeExpr {
+		c.errorf(args[0].expr, "first argument to make must be a type, got %s", args[0].typ)
+		return p
+	}
+	t, ok := args[0].typ.(*tipe.Table)
+	if !ok {
+		c.errorf(args[0].expr, "cannot make type %s", args[0].typ)
+		return p
+	}
+	for _, sz := range args[1:] {
+		if !tipe.IsNumeric(sz.typ) {
+			c.errorf(sz.expr, "make size argument must be an integer, got %s", sz.typ)
+			return p
+		}
+	}
+	p.mode = modeVar
+	p.typ = t
+	return p
+}
+
+func builtinNew(c *Checker, call *expr.Call, args []operand) operand {
+	p := operand{mode: modeInvalid, expr: call}
+	if len(args) != 1 {
+		c.errorf(call, "new takes exactly one argument, got %d", len(args))
+		return p
+	}
+	if args[0].mode != modeTypeExpr {
+		c.errorf(args[0].expr, "argument to new must be a type, got %s", args[0].typ)
+		return p
+	}
+	p.mode = modeVar
+	p.typ = &tipe.Pointer{Elem: args[0].typ}
+	return p
+}
+
+func builtinLen(c *Checker, call *expr.Call, args []operand) operand {
+	p := operand{mode: modeInvalid, expr: call}
+	if len(args) != 1 {
+		c.errorf(call, "len takes exactly one argument, got %d", len(args))
+		return p
+	}
+	switch t := args[0].typ.(type) {
+	case *tipe.Table:
+		// ok
+	case tipe.Basic:
+		if t != tipe.String {
+			c.errorf(args[0].expr, "invalid argument %s for len", args[0].typ)
+			return p
+		}
+	default:
+		c.errorf(args[0].expr, "invalid argument %s for len", args[0].typ)
+		return p
+	}
+	p.mode = modeVar
+	p.typ = tipe.Int64
+	return p
+}
+
+func builtinCap(c *Checker, call *expr.Call, args []operand) operand {
+	p := operand{mode: modeInvalid, expr: call}
+	if len(args) != 1 {
+		c.errorf(call, "cap takes exactly one argument, got %d", len(args))
+		return p
+	}
+	if _, ok := args[0].typ.(*tipe.Table); !ok {
+		c.errorf(args[0].expr, "invalid argument %s for cap", args[0].typ)
+		return p
+	}
+	p.mode = modeVar
+	p.typ = tipe.Int64
+	return p
+}
+
+func builtinAppend(c *Checker, call *expr.Call, args []operand) operand {
+	p := operand{mode: modeInvalid, expr: call}
+	if len(args) == 0 {
+		c.errorf(call, "missing arguments to append")
+		return p
+	}
+	t, ok := args[0].typ.(*tipe.Table)
+	if !ok {
+		c.errorf(args[0].expr, "first argument to append must be a table, got %s", args[0].typ)
+		return p
+	}
+	for _, elem := range args[1:] {
+		c.constrainUntyped(&elem, t.Type)
+		if elem.mode == modeInvalid || !Identical(elem.typ, t.Type) {
+			c.errorf(elem.expr, "cannot use %s as %s in append", elem.typ, t.Type)
+			return p
+		}
+	}
+	p.mode = modeVar
+	p.typ = t
+	return p
+}
+
+func builtinCopy(c *Checker, call *expr.Call, args []operand) operand {
+	p := operand{mode: modeInvalid, expr: call}
+	if len(args) != 2 {
+		c.errorf(call, "copy takes exactly two arguments, got %d", len(args))
+		return p
+	}
+	dst, ok := args[0].typ.(*tipe.Table)
+	if !ok {
+		c.errorf(args[0].expr, "first argument to copy must be a table, got %s", args[0].typ)
+		return p
+	}
+	src, ok := args[1].typ.(*tipe.Table)
+	if !ok {
+		c.errorf(args[1].expr, "second argument to copy must be a table, got %s", args[1].typ)
+		return p
+	}
+	if !Identical(dst.Type, src.Type) {
+		c.errorf(args[1].expr, "copy arguments have different element types, %s and %s", dst.Type, src.Type)
+		return p
+	}
+	p.mode = modeVar
+	p.typ = tipe.Int64
+	return p
+}
+
+func builtinDelete(c *Checker, call *expr.Call, args []operand) operand {
+	p := operand{mode: modeInvalid, expr: call}
+	if len(args) != 2 {
+		c.errorf(call, "delete takes exactly two arguments, got %d", len(args))
+		return p
+	}
+	m, ok := args[0].typ.(*tipe.Map)
+	if !ok {
+		c.errorf(args[0].expr, "first argument to delete must be a map, got %s", args[0].typ)
+		return p
+	}
+	key := args[1]
+	c.constrainUntyped(&key, m.Key)
+	if key.mode == modeInvalid || !Identical(key.typ, m.Key) {
+		c.errorf(key.expr, "cannot use %s as %s in delete", key.typ, m.Key)
+		p.mode = modeInvalid
+		return p
+	}
+	p.mode = modeVoid
+	return p
+}
+
 type ObjKind int
 
 const (
 	ObjUnknown ObjKind = iota
 	ObjVar
 	ObjType
+	ObjBuiltin
 )
 
 func (o ObjKind) String() string {
@@ -880,6 +1432,8 @@ func (o ObjKind) String() string {
 		return "ObjVar"
 	case ObjType:
 		return "ObjType"
+	case ObjBuiltin:
+		return "ObjBuiltin"
 	default:
 		return fmt.Sprintf("ObjKind(%d)", int(o))
 	}
@@ -0,0 +1,81 @@
+// Copyright 2015 The Numgrad Authors. All rights reserved.
+// See the LICENSE file for rights to use this source code.
+
+package typecheck
+
+import (
+	gotoken "go/token"
+	"testing"
+
+	"numgrad.io/lang/expr"
+	"numgrad.io/lang/stmt"
+)
+
+// TestGoImportCallSites checks that identifiers exported from real Go
+// packages resolve and typecheck at their use sites: a func call
+// (fmt.Println), a func call with two arguments (strings.Contains), and
+// an untyped constant (math.Pi).
+func TestGoImportCallSites(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  string
+		stmt stmt.Stmt
+	}{
+		{
+			name: "fmt.Println",
+			pkg:  "fmt",
+			stmt: &stmt.Simple{
+				Expr: &expr.Call{
+					Func: &expr.Selector{
+						Left:  &expr.Ident{Name: "fmt"},
+						Right: &expr.Ident{Name: "Println"},
+					},
+					Args: []expr.Expr{
+						&expr.BasicLiteral{Value: "hello"},
+					},
+				},
+			},
+		},
+		{
+			name: "strings.Contains",
+			pkg:  "strings",
+			stmt: &stmt.Simple{
+				Expr: &expr.Call{
+					Func: &expr.Selector{
+						Left:  &expr.Ident{Name: "strings"},
+						Right: &expr.Ident{Name: "Contains"},
+					},
+					Args: []expr.Expr{
+						&expr.BasicLiteral{Value: "seafood"},
+						&expr.BasicLiteral{Value: "foo"},
+					},
+				},
+			},
+		},
+		{
+			name: "math.Pi",
+			pkg:  "math",
+			stmt: &stmt.Assign{
+				Decl: true,
+				Left: []expr.Expr{&expr.Ident{Name: "x"}},
+				Right: []expr.Expr{
+					&expr.Selector{
+						Left:  &expr.Ident{Name: "math"},
+						Right: &expr.Ident{Name: "Pi"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(gotoken.NewFileSet())
+			c.Add(&stmt.Import{Path: tt.pkg, FromGo: true})
+			c.Add(tt.stmt)
+			if len(c.Errs) != 0 {
+				t.Fatalf("unexpected errors: %v", c.Errs)
+			}
+		})
+	}
+}